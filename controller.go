@@ -0,0 +1,436 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+	clientset "github.com/munnerz/k8s-api-pager-demo/pkg/client"
+	alertapply "github.com/munnerz/k8s-api-pager-demo/pkg/client/applyconfiguration/pager/v1alpha1"
+	factory "github.com/munnerz/k8s-api-pager-demo/pkg/informers/externalversions"
+	alertlisters "github.com/munnerz/k8s-api-pager-demo/pkg/listers/pager/v1alpha1"
+	"github.com/munnerz/k8s-api-pager-demo/pkg/notifier"
+)
+
+// controllerAgentName is used as the component name in Events recorded by
+// this controller.
+const controllerAgentName = "k8s-api-pager"
+
+// fieldManager identifies this controller's writes in an Alert's
+// managedFields, so it can server-side-apply status updates without
+// stomping on fields owned by other writers of the same Alert.
+const fieldManager = "k8s-api-pager-demo"
+
+// Controller watches Alert resources and pages whoever is configured to
+// receive them. It owns the workqueue, the shared informer's lister, and a
+// Kubernetes event recorder so progress can be observed both via logs and
+// via Events on the Alert objects themselves.
+type Controller struct {
+	pagerclient   clientset.Interface
+	kubeClientset kubernetes.Interface
+
+	alertsLister alertlisters.AlertLister
+	alertsSynced cache.InformerSynced
+
+	queue workqueue.TypedRateLimitingInterface[cache.ObjectName]
+
+	recorder record.EventRecorder
+
+	// notifiers are the default, flag-configured Notifiers fanned out to on
+	// every Alert. baseConfig is the flag-derived Config they were built
+	// from; per-Alert overrides named on the Alert's spec are built from the
+	// referenced Secret/ConfigMap alone and do not read it.
+	notifiers  []notifier.Notifier
+	baseConfig notifier.Config
+
+	// enqueuedAt tracks when each key was first added to the queue since it
+	// was last popped off, so workqueueLatency can measure queueing delay.
+	enqueuedAt sync.Map
+}
+
+// NewController returns a new pager controller, wired up to watch Alerts
+// through the given informer factory. kubeClientset is used to emit Events
+// against the core API and to resolve per-Alert notifier config overrides;
+// all Alert reads/writes go through pagerclient.
+func NewController(
+	pagerclient clientset.Interface,
+	kubeClientset kubernetes.Interface,
+	sharedFactory factory.SharedInformerFactory,
+	notifiers []notifier.Notifier,
+	baseConfig notifier.Config,
+) *Controller {
+	alertInformer := sharedFactory.Pager().V1alpha1().Alerts()
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(klog.Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
+	rateLimiter := workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[cache.ObjectName](5*time.Second, time.Minute),
+		&workqueue.TypedBucketRateLimiter[cache.ObjectName]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+
+	c := &Controller{
+		pagerclient:   pagerclient,
+		kubeClientset: kubeClientset,
+		alertsLister:  alertInformer.Lister(),
+		alertsSynced:  alertInformer.Informer().HasSynced,
+		queue:         workqueue.NewTypedRateLimitingQueue[cache.ObjectName](rateLimiter),
+		recorder:      recorder,
+		notifiers:     notifiers,
+		baseConfig:    baseConfig,
+	}
+
+	alertInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					c.enqueue(cur)
+				}
+			},
+			DeleteFunc: c.enqueue,
+		},
+	)
+
+	return c
+}
+
+// Run waits for the informer caches to sync, then launches workers
+// goroutines to process items from the queue until ctx is cancelled. It
+// blocks until all workers have exited and the queue has drained.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer runtime.HandleCrash()
+
+	klog.InfoS("starting pager controller")
+
+	klog.InfoS("waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(ctx.Done(), c.alertsSynced); !ok {
+		return fmt.Errorf("failed waiting for caches to sync")
+	}
+
+	klog.InfoS("starting workers", "count", workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait.UntilWithContext(ctx, c.runWorker, time.Second)
+		}()
+	}
+
+	go c.reportQueueDepth(ctx)
+
+	klog.InfoS("started workers")
+	<-ctx.Done()
+	klog.InfoS("shutting down workers")
+
+	// ShutDown unblocks any worker parked in queue.Get(); wg.Wait() then
+	// blocks until every worker has finished the item it was mid-flight on
+	// and returned, so Run doesn't hand control back to main() early.
+	c.queue.ShutDown()
+	wg.Wait()
+
+	return nil
+}
+
+// reportQueueDepth periodically samples the workqueue length into the
+// workqueueDepth gauge, since the typed workqueue has no push-based hook for
+// depth.
+func (c *Controller) reportQueueDepth(ctx context.Context) {
+	wait.Until(func() {
+		workqueueDepth.Set(float64(c.queue.Len()))
+	}, 5*time.Second, ctx.Done())
+}
+
+// runWorker is a long-running function that repeatedly calls
+// processNextWorkItem until it returns false, meaning the queue has been
+// shut down.
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if addedAt, ok := c.enqueuedAt.LoadAndDelete(key); ok {
+		workqueueLatency.Observe(time.Since(addedAt.(time.Time)).Seconds())
+	}
+
+	if err := c.syncHandler(ctx, key); err != nil {
+		attempts := c.queue.NumRequeues(key)
+		klog.ErrorS(err, "error syncing alert, requeuing", "namespace", key.Namespace, "name", key.Name, "attempts", attempts)
+		workqueueRetries.Inc()
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// syncHandler reconciles the Alert named by key against the desired state:
+// it fans the Alert out to the configured Notifier(s), records the result
+// on the Alert's status subresource, and emits a matching Event.
+func (c *Controller) syncHandler(ctx context.Context, key cache.ObjectName) error {
+	start := time.Now()
+	defer func() { syncDuration.Observe(time.Since(start).Seconds()) }()
+
+	alert, err := c.alertsLister.Alerts(key.Namespace).Get(key.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			klog.InfoS("alert in work queue no longer exists", "namespace", key.Namespace, "name", key.Name)
+			return nil
+		}
+		reconcileErrors.Inc()
+		return err
+	}
+
+	// already delivered for this generation: nothing to do. This keeps a
+	// resync (every 30s) from re-paging on an Alert nobody has touched.
+	if alert.Status.Phase == v1alpha1.AlertPhaseSent && alert.Status.ObservedGeneration == alert.Generation {
+		return nil
+	}
+
+	notifyErr := c.notifyAlert(ctx, alert)
+
+	if statusErr := c.updateAlertStatus(ctx, alert, notifyErr); statusErr != nil {
+		klog.ErrorS(statusErr, "error updating alert status", "namespace", key.Namespace, "name", key.Name)
+	}
+
+	if notifyErr != nil {
+		reconcileErrors.Inc()
+		c.recorder.Eventf(alert, corev1.EventTypeWarning, "SyncFailed", "failed to notify alert: %s", notifyErr.Error())
+		return notifyErr
+	}
+
+	c.recorder.Event(alert, corev1.EventTypeNormal, "Synced", "successfully notified alert")
+	return nil
+}
+
+// notifyAlert delivers alert to every Notifier configured by flags, with any
+// types overridden by the Alert's notifierRef replaced by the override. It
+// returns the first error encountered, having still attempted every
+// Notifier.
+func (c *Controller) notifyAlert(ctx context.Context, alert *v1alpha1.Alert) error {
+	notifiers := c.notifiers
+
+	if alert.Spec.NotifierRef != nil {
+		overrides, err := c.notifierForAlert(ctx, alert)
+		if err != nil {
+			return fmt.Errorf("error building notifier from notifierRef: %s", err.Error())
+		}
+		notifiers = mergeNotifiers(notifiers, overrides)
+	}
+
+	var firstErr error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, alert); err != nil {
+			notifyFailure.WithLabelValues(n.Name()).Inc()
+			klog.ErrorS(err, "error notifying alert", "namespace", alert.Namespace, "name", alert.Name, "notifier", n.Name())
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		notifySuccess.WithLabelValues(n.Name()).Inc()
+	}
+
+	return firstErr
+}
+
+// mergeNotifiers returns defaults with any entry sharing a Name() with an
+// override replaced by that override, plus any override types not already
+// present in defaults. This is what lets a notifierRef override just the
+// Slack destination for one Alert without double-paging PagerDuty via both
+// the flag-configured default and the override.
+func mergeNotifiers(defaults, overrides []notifier.Notifier) []notifier.Notifier {
+	merged := make([]notifier.Notifier, 0, len(defaults)+len(overrides))
+	seen := make(map[string]bool, len(overrides))
+	for _, n := range overrides {
+		seen[n.Name()] = true
+	}
+	for _, n := range defaults {
+		if !seen[n.Name()] {
+			merged = append(merged, n)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+// notifierForAlert resolves the Secret or ConfigMap referenced by the
+// Alert's spec and constructs one Notifier per notifier type that the
+// override actually configures. Unlike the default, flag-configured
+// notifiers, it never falls back to c.baseConfig: an override that only
+// sets "slack-webhook" must build only a slack notifier, not silently pick
+// up an unrelated --pagerduty-routing-key from the process flags.
+func (c *Controller) notifierForAlert(ctx context.Context, alert *v1alpha1.Alert) ([]notifier.Notifier, error) {
+	ref := alert.Spec.NotifierRef
+
+	data := map[string][]byte{}
+	switch ref.Kind {
+	case "Secret":
+		secret, err := c.kubeClientset.CoreV1().Secrets(alert.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data = secret.Data
+	case "ConfigMap":
+		cm, err := c.kubeClientset.CoreV1().ConfigMaps(alert.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported notifierRef kind %q", ref.Kind)
+	}
+
+	var cfg notifier.Config
+	var names []string
+
+	if v, ok := data["slack-webhook"]; ok {
+		cfg.SlackWebhookURL = string(v)
+		names = append(names, "slack")
+	}
+	if v, ok := data["pagerduty-routing-key"]; ok {
+		cfg.PagerDutyRoutingKey = string(v)
+		names = append(names, "pagerduty")
+	}
+	if v, ok := data["webhook-url"]; ok {
+		cfg.WebhookURL = string(v)
+		names = append(names, "webhook")
+	}
+	if _, ok := data["smtp-host"]; ok {
+		cfg.SMTPHost = string(data["smtp-host"])
+		cfg.SMTPUsername = string(data["smtp-username"])
+		cfg.SMTPPassword = string(data["smtp-password"])
+		cfg.SMTPFrom = string(data["smtp-from"])
+		if v, ok := data["smtp-to"]; ok {
+			cfg.SMTPTo = strings.Split(string(v), ",")
+		}
+		names = append(names, "email")
+	}
+
+	notifiers := make([]notifier.Notifier, 0, len(names))
+	for _, name := range names {
+		n, err := notifier.New(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error building %s notifier from notifierRef: %s", name, err.Error())
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	return notifiers, nil
+}
+
+// updateAlertStatus records the outcome of a notification attempt on the
+// Alert's status subresource via server-side apply, so that several
+// controllers (or a human running kubectl) writing to the same Alert don't
+// conflict with one another.
+func (c *Controller) updateAlertStatus(ctx context.Context, alert *v1alpha1.Alert, notifyErr error) error {
+	now := metav1.Now()
+
+	next := v1alpha1.AlertCondition{
+		Type:               v1alpha1.AlertConditionNotified,
+		LastTransitionTime: now,
+	}
+
+	status := alertapply.AlertStatus().
+		WithObservedGeneration(alert.Generation).
+		WithLastNotifiedTime(now)
+
+	if notifyErr != nil {
+		status.WithPhase(v1alpha1.AlertPhaseFailed)
+		next.Status = corev1.ConditionFalse
+		next.Reason = "NotifyFailed"
+		next.Message = notifyErr.Error()
+	} else {
+		status.WithPhase(v1alpha1.AlertPhaseSent)
+		next.Status = corev1.ConditionTrue
+		next.Reason = "NotifySucceeded"
+		next.Message = "successfully notified all configured notifiers"
+	}
+
+	conditions := setAlertCondition(alert.Status.Conditions, next)
+	applyConditions := make([]*alertapply.AlertConditionApplyConfiguration, 0, len(conditions))
+	for _, c := range conditions {
+		applyConditions = append(applyConditions, alertapply.AlertCondition().
+			WithType(c.Type).
+			WithStatus(c.Status).
+			WithLastTransitionTime(c.LastTransitionTime).
+			WithReason(c.Reason).
+			WithMessage(c.Message))
+	}
+	status.WithConditions(applyConditions...)
+
+	applyConfig := alertapply.Alert(alert.Name, alert.Namespace).WithStatus(status)
+
+	_, err := c.pagerclient.PagerV1alpha1().Alerts(alert.Namespace).ApplyStatus(ctx, applyConfig, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+	})
+	return err
+}
+
+// setAlertCondition returns conditions with next inserted in place of the
+// existing entry of the same Type (preserving LastTransitionTime if the
+// Status didn't change), or appended if no such entry exists. This mirrors
+// the standard apimachinery meta.SetStatusCondition update-or-insert
+// pattern, keeping the condition list bounded at one entry per Type instead
+// of growing without bound across reconciles.
+func setAlertCondition(conditions []v1alpha1.AlertCondition, next v1alpha1.AlertCondition) []v1alpha1.AlertCondition {
+	merged := make([]v1alpha1.AlertCondition, len(conditions))
+	copy(merged, conditions)
+
+	for i, c := range merged {
+		if c.Type != next.Type {
+			continue
+		}
+		if c.Status == next.Status {
+			next.LastTransitionTime = c.LastTransitionTime
+		}
+		merged[i] = next
+		return merged
+	}
+
+	return append(merged, next)
+}
+
+// enqueue adds an object to the workqueue. The object being added must be
+// of type metav1.Object, metav1.ObjectAccessor or cache.ExplicitKey.
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingObjectToName(obj)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueued: %s", err.Error()))
+		return
+	}
+	workqueueAdds.Inc()
+	c.enqueuedAt.LoadOrStore(key, time.Now())
+	c.queue.Add(key)
+}
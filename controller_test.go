@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+	"github.com/munnerz/k8s-api-pager-demo/pkg/notifier"
+)
+
+// fakeNotifier is a minimal notifier.Notifier stand-in for exercising
+// mergeNotifiers without constructing real Slack/PagerDuty/email clients.
+type fakeNotifier struct{ name string }
+
+func (f fakeNotifier) Name() string                                            { return f.name }
+func (f fakeNotifier) Notify(ctx context.Context, alert *v1alpha1.Alert) error { return nil }
+
+func TestMergeNotifiers(t *testing.T) {
+	slackDefault := fakeNotifier{"slack"}
+	pagerdutyDefault := fakeNotifier{"pagerduty"}
+	slackOverride := fakeNotifier{"slack"}
+	emailOverride := fakeNotifier{"email"}
+
+	tests := []struct {
+		name      string
+		defaults  []notifier.Notifier
+		overrides []notifier.Notifier
+		want      []notifier.Notifier
+	}{
+		{
+			name:      "no overrides returns defaults unchanged",
+			defaults:  []notifier.Notifier{slackDefault, pagerdutyDefault},
+			overrides: nil,
+			want:      []notifier.Notifier{slackDefault, pagerdutyDefault},
+		},
+		{
+			name:      "override replaces same-type default instead of adding to it",
+			defaults:  []notifier.Notifier{slackDefault, pagerdutyDefault},
+			overrides: []notifier.Notifier{slackOverride},
+			want:      []notifier.Notifier{pagerdutyDefault, slackOverride},
+		},
+		{
+			name:      "override of a type with no default is appended",
+			defaults:  []notifier.Notifier{slackDefault},
+			overrides: []notifier.Notifier{emailOverride},
+			want:      []notifier.Notifier{slackDefault, emailOverride},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeNotifiers(tt.defaults, tt.overrides)
+			if len(got) != len(tt.want) {
+				t.Fatalf("mergeNotifiers() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Name() != tt.want[i].Name() {
+					t.Errorf("mergeNotifiers()[%d].Name() = %q, want %q", i, got[i].Name(), tt.want[i].Name())
+				}
+			}
+		})
+	}
+}
+
+func TestNotifierForAlert(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"},
+		Data: map[string][]byte{
+			"slack-webhook": []byte("https://hooks.example/override"),
+			"smtp-host":     []byte("smtp.example.com"),
+			"smtp-to":       []byte("oncall@example.com,backup@example.com"),
+		},
+	}
+
+	c := &Controller{kubeClientset: fake.NewSimpleClientset(secret)}
+	alert := &v1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.AlertSpec{
+			NotifierRef: &v1alpha1.NotifierReference{Kind: "Secret", Name: "overrides"},
+		},
+	}
+
+	notifiers, err := c.notifierForAlert(context.Background(), alert)
+	if err != nil {
+		t.Fatalf("notifierForAlert() error = %v", err)
+	}
+
+	got := make(map[string]bool, len(notifiers))
+	for _, n := range notifiers {
+		got[n.Name()] = true
+	}
+
+	// Only slack and email were configured in the Secret; pagerduty and
+	// webhook must not be built even if the controller's baseConfig has
+	// them set from flags.
+	want := map[string]bool{"slack": true, "email": true}
+	if len(got) != len(want) {
+		t.Fatalf("notifierForAlert() built %v, want %v", got, want)
+	}
+	for name := range want {
+		if !got[name] {
+			t.Errorf("notifierForAlert() did not build a %q notifier", name)
+		}
+	}
+}
+
+func TestSetAlertCondition(t *testing.T) {
+	first := v1alpha1.AlertCondition{
+		Type:    v1alpha1.AlertConditionNotified,
+		Status:  corev1.ConditionFalse,
+		Reason:  "NotifyFailed",
+		Message: "first attempt failed",
+	}
+	first.LastTransitionTime = metav1.Now()
+
+	t.Run("inserts when no condition of the type exists", func(t *testing.T) {
+		got := setAlertCondition(nil, first)
+		if len(got) != 1 || got[0] != first {
+			t.Fatalf("setAlertCondition() = %v, want [%v]", got, first)
+		}
+	})
+
+	t.Run("updates in place without growing the slice", func(t *testing.T) {
+		second := first
+		second.Status = corev1.ConditionTrue
+		second.Reason = "NotifySucceeded"
+		second.Message = "second attempt succeeded"
+
+		got := setAlertCondition([]v1alpha1.AlertCondition{first}, second)
+		if len(got) != 1 {
+			t.Fatalf("setAlertCondition() len = %d, want 1 (conditions must not accumulate unbounded history)", len(got))
+		}
+		if got[0].Status != corev1.ConditionTrue || got[0].Reason != "NotifySucceeded" {
+			t.Fatalf("setAlertCondition() = %v, want updated condition", got[0])
+		}
+	})
+
+	t.Run("preserves LastTransitionTime when status is unchanged", func(t *testing.T) {
+		unchanged := first
+		unchanged.LastTransitionTime = metav1.Now()
+
+		got := setAlertCondition([]v1alpha1.AlertCondition{first}, unchanged)
+		if got[0].LastTransitionTime != first.LastTransitionTime {
+			t.Fatalf("setAlertCondition() LastTransitionTime = %v, want unchanged %v", got[0].LastTransitionTime, first.LastTransitionTime)
+		}
+	})
+}
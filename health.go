@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// shutdownTimeout bounds how long the metrics/health servers wait for
+// in-flight requests to finish when the controller is shutting down.
+const shutdownTimeout = 5 * time.Second
+
+// serveMetrics serves Prometheus metrics on addr until ctx is cancelled. It
+// is a no-op if addr is empty.
+func serveMetrics(ctx context.Context, addr string) {
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	serveUntilDone(ctx, "metrics", addr, mux)
+}
+
+// serveHealthProbes serves /healthz and /readyz on addr until ctx is
+// cancelled. /healthz always reports ok once the process is up; /readyz
+// additionally waits for the Alert informer cache to have synced. It is a
+// no-op if addr is empty.
+func serveHealthProbes(ctx context.Context, addr string, alertsSynced cache.InformerSynced) {
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !alertsSynced() {
+			http.Error(w, "informer cache not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	serveUntilDone(ctx, "health probe", addr, mux)
+}
+
+func serveUntilDone(ctx context.Context, name, addr string, handler http.Handler) {
+	srv := &http.Server{Addr: addr, Handler: handler}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "error shutting down server", "server", name, "address", addr)
+		}
+	}()
+
+	klog.InfoS("starting server", "server", name, "address", addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.ErrorS(err, "server exited unexpectedly", "server", name, "address", addr)
+	}
+}
@@ -1,157 +1,258 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"log"
-	"reflect"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
 
-	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
 	"github.com/munnerz/k8s-api-pager-demo/pkg/client"
 	factory "github.com/munnerz/k8s-api-pager-demo/pkg/informers/externalversions"
+	"github.com/munnerz/k8s-api-pager-demo/pkg/notifier"
 )
 
 var (
-	// apiserverURL is the URL of the API server to connect to
-	apiserverURL = flag.String("apiserver", "http://127.0.0.1:8001", "URL used to access the Kubernetes API server")
+	// apiserverURL optionally overrides the API server host recorded in the
+	// kubeconfig (or in-cluster config). Mirrors the standard controller
+	// flag pair used throughout client-go based tooling.
+	apiserverURL = flag.String("apiserver", "", "optional URL used to override the API server host read from --kubeconfig")
+	kubeconfig   = flag.String("kubeconfig", "", "path to a kubeconfig file; if unset, falls back to in-cluster config")
 
-	// queue is a queue of resources to be processed. It is the most simple of
-	// types of queue and performs no rate limiting.
-	queue = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute))
+	// namespace restricts the informer to a single namespace; empty means
+	// watch Alerts across the whole cluster.
+	namespace = flag.String("namespace", "", "namespace to watch for Alerts, empty means all namespaces")
 
-	// stopCh can be used to stop all the informer, as well as control loops
-	// within the application.
-	stopCh = make(chan struct{})
+	labelSelector = flag.String("label-selector", "", "label selector used to filter which Alerts are watched")
+	fieldSelector = flag.String("field-selector", "", "field selector used to filter which Alerts are watched")
 
-	sharedFactory factory.SharedInformerFactory
+	// workers is the number of worker goroutines processing items off the
+	// queue concurrently.
+	workers = flag.Int("workers", 2, "number of Alert sync workers to run")
+
+	// leaderElect gates leaderelection, allowing multiple replicas of the
+	// pager to run HA with only one actively reconciling at a time.
+	leaderElect          = flag.Bool("leader-elect", false, "enable leader election so multiple replicas can run HA")
+	leaderElectNamespace = flag.String("leader-elect-namespace", "default", "namespace used to hold the leader election lock")
+	leaderElectID        = flag.String("leader-elect-id", "", "identity used in the leader election lock, defaults to <hostname>_<pid>")
+
+	// notifierNames is a comma-separated list of notifier.Names() to fan
+	// every Alert out to by default, e.g. "slack,pagerduty".
+	notifierNames = flag.String("notifier", "", "comma-separated list of notifiers to send Alerts to (slack, pagerduty, email, webhook)")
+
+	slackWebhook        = flag.String("slack-webhook", "", "Slack incoming webhook URL, used by the slack notifier")
+	pagerdutyRoutingKey = flag.String("pagerduty-routing-key", "", "PagerDuty Events API v2 routing key, used by the pagerduty notifier")
+	webhookURL          = flag.String("webhook-url", "", "destination URL for the generic webhook notifier")
+	smtpHost            = flag.String("smtp-host", "", "SMTP server host, used by the email notifier")
+	smtpPort            = flag.Int("smtp-port", 587, "SMTP server port, used by the email notifier")
+	smtpUsername        = flag.String("smtp-username", "", "SMTP auth username, used by the email notifier")
+	smtpPassword        = flag.String("smtp-password", "", "SMTP auth password, used by the email notifier")
+	smtpFrom            = flag.String("smtp-from", "", "From address for alert emails, used by the email notifier")
+	smtpTo              = flag.String("smtp-to", "", "comma-separated list of recipient addresses, used by the email notifier")
+
+	metricsBindAddress     = flag.String("metrics-bind-address", ":8080", "address to serve Prometheus metrics on, set to \"\" to disable")
+	healthProbeBindAddress = flag.String("health-probe-bind-address", ":8081", "address to serve /healthz and /readyz on, set to \"\" to disable")
 )
 
 func main() {
+	klog.InitFlags(nil)
 	flag.Parse()
 
-	// create an instance of our own API client
-	cl, err := client.NewForConfig(&rest.Config{
-		Host: *apiserverURL,
-	})
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cfg, err := buildConfig(*kubeconfig, *apiserverURL)
+	if err != nil {
+		klog.Fatalf("error building kubeconfig: %s", err.Error())
+	}
+
+	pagerclient, err := client.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("error creating pager api client: %s", err.Error())
+	}
 
+	kubeClientset, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
-		log.Fatalf("error creating api client: %s", err.Error())
+		klog.Fatalf("error creating kubernetes client: %s", err.Error())
+	}
+
+	baseConfig := notifierConfigFromFlags()
+
+	var notifiers []notifier.Notifier
+	for _, name := range strings.Split(*notifierNames, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		n, err := notifier.New(name, baseConfig)
+		if err != nil {
+			klog.Fatalf("error configuring notifier: %s", err.Error())
+		}
+		notifiers = append(notifiers, n)
 	}
 
 	// we use a shared informer from the informer factory, to save calls to the
 	// API as we grow our application and so state is consistent between our
 	// control loops. We set a resync period of 30 seconds, in case any
-	// create/replace/update/delete operations are missed when watching
-	sharedFactory = factory.NewSharedInformerFactory(cl, time.Second*30)
-
-	informer := sharedFactory.Pager().V1alpha1().Alerts().Informer()
-	// we add a new event handler, watching for changes to API resources.
-	informer.AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc: enqueue,
-			UpdateFunc: func(old, cur interface{}) {
-				if !reflect.DeepEqual(old, cur) {
-					enqueue(cur)
-				}
-			},
-			DeleteFunc: enqueue,
-		},
+	// create/replace/update/delete operations are missed when watching.
+	// WithNamespace/WithTweakListOptions let operators restrict the pager to
+	// a single namespace or a subset of Alerts.
+	sharedFactory := factory.NewSharedInformerFactoryWithOptions(pagerclient, time.Second*30,
+		factory.WithNamespace(*namespace),
+		factory.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = *labelSelector
+			opts.FieldSelector = *fieldSelector
+		}),
 	)
 
+	controller := NewController(pagerclient, kubeClientset, sharedFactory, notifiers, baseConfig)
+
 	// start the informer. This will cause it to begin receiving updates from
 	// the configured API server and firing event handlers in response.
-	sharedFactory.Start(stopCh)
+	sharedFactory.Start(ctx.Done())
+
+	go serveMetrics(ctx, *metricsBindAddress)
+	go serveHealthProbes(ctx, *healthProbeBindAddress, controller.alertsSynced)
 
-	// wait for the informe rcache to finish performing it's initial sync of
-	// resources
-	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
-		log.Fatalf("error waiting for informer cache to sync: %s", err.Error())
+	run := func(ctx context.Context) {
+		if err := controller.Run(ctx, *workers); err != nil {
+			klog.Fatalf("error running controller: %s", err.Error())
+		}
 	}
 
-	// here we start just one worker reading objects of the queue. If you
-	// wanted to parallelize this, you could start many instances of the worker
-	// function, then ensure your application handles concurrency correctly.
-	work()
-}
+	if !*leaderElect {
+		run(ctx)
+		return
+	}
 
-func sync(al *v1alpha1.Alert) error {
-	log.Printf("got alert! %+v", al)
-	return nil
+	if err := runWithLeaderElection(ctx, cfg, kubeClientset, run); err != nil {
+		klog.Fatalf("error running with leader election: %s", err.Error())
+	}
 }
 
-func work() {
-	for {
-		// we read a message off the queue
-		key, shutdown := queue.Get()
+// buildConfig resolves a *rest.Config the standard client-go way: from
+// kubeconfigPath if given, falling back to in-cluster config (for running as
+// a Deployment with a ServiceAccount), with apiserverURL as an optional
+// override of the resulting host, mainly useful with `kubectl proxy`.
+func buildConfig(kubeconfigPath, apiserverURL string) (*rest.Config, error) {
+	if kubeconfigPath != "" {
+		return clientcmd.BuildConfigFromFlags(apiserverURL, kubeconfigPath)
+	}
+
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("not running in-cluster and no --kubeconfig given: %s", err.Error())
+	}
 
-		// if the queue has been shut down, we should exit the work queue here
-		if shutdown {
-			stopCh <- struct{}{}
-			return
-		}
+	if apiserverURL != "" {
+		cfg.Host = apiserverURL
+	}
+
+	return cfg, nil
+}
 
-		// convert the queue item into a string. If it's not a string, we'll
-		// simply discard it as invalid data and log a message.
-		var strKey string
-		var ok bool
-		if strKey, ok = key.(string); !ok {
-			runtime.HandleError(fmt.Errorf("key in queue should be of type string but got %T. discarding", key))
-			return
+// runWithLeaderElection wraps run so that it is only invoked while this
+// process holds the "k8s-api-pager-lock" lock, allowing several replicas of the
+// pager to be deployed for HA with only the leader actively reconciling.
+func runWithLeaderElection(ctx context.Context, cfg *rest.Config, kubeClientset kubernetes.Interface, run func(ctx context.Context)) error {
+	id := *leaderElectID
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("error determining leader election identity: %s", err.Error())
 		}
+		id = fmt.Sprintf("%s_%d", hostname, os.Getpid())
+	}
 
-		// we define a function here to process a queue item, so that we can
-		// use 'defer' to make sure the message is marked as Done on the queue
-		func(key string) {
-			defer queue.Done(key)
-
-			// attempt to split the 'key' into namespace and object name
-			namespace, name, err := cache.SplitMetaNamespaceKey(strKey)
-
-			if err != nil {
-				runtime.HandleError(fmt.Errorf("error splitting meta namespace key into parts: %s", err.Error()))
-				return
-			}
-
-			// retrieve the latest version in the cache of this alert
-			obj, err := sharedFactory.Pager().V1alpha1().Alerts().Lister().Alerts(namespace).Get(name)
-
-			if err != nil {
-				runtime.HandleError(fmt.Errorf("error getting object '%s/%s' from api: %s", namespace, name, err.Error()))
-				return
-			}
-
-			// attempt to sync the current state of the world with the desired!
-			if err := sync(obj); err != nil {
-				runtime.HandleError(fmt.Errorf("error processing item '%s/%s': %s", namespace, name, err.Error()))
-				return
-			}
-
-			// as we managed to process this successfully, we can forget it
-			// from the work queue altogether.
-			queue.Forget(key)
-		}(strKey)
+	// Use the combined configmaps-leases lock rather than a bare Lease so
+	// that replicas upgrading from the older ConfigMap-based lock observe
+	// (and write) both objects during the migration window, instead of
+	// splitting the fleet across two different lock objects.
+	lock, err := resourcelock.New(
+		resourcelock.ConfigMapsLeasesResourceLock,
+		*leaderElectNamespace,
+		"k8s-api-pager-lock",
+		kubeClientset.CoreV1(),
+		kubeClientset.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error building leader election lock: %s", err.Error())
 	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				klog.InfoS("leadership lost, shutting down", "identity", id)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != id {
+					klog.InfoS("observed new leader", "identity", identity)
+				}
+			},
+		},
+	})
+
+	return nil
 }
 
-// enqueue will add an object 'obj' into the workqueue. The object being added
-// must be of type metav1.Object, metav1.ObjectAccessor or cache.ExplicitKey.
-func enqueue(obj interface{}) {
-	// DeletionHandlingMetaNamespaceKeyFunc will convert an object into a
-	// 'namespace/name' string. We do this because our item may be processed
-	// much later than now, and so we want to ensure it gets a fresh copy of
-	// the resource when it starts. Also, this allows us to keep adding the
-	// same item into the work queue without duplicates building up.
-	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err != nil {
-		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueue: %s", err.Error()))
-		return
+// signalContext returns a context that is cancelled when SIGINT or SIGTERM
+// is received, giving the controller a chance to drain in-flight work and
+// shut the queue down cleanly instead of being killed outright.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ch
+		klog.InfoS("received shutdown signal")
+		cancel()
+		<-ch
+		os.Exit(1)
+	}()
+
+	return ctx, cancel
+}
+
+// notifierConfigFromFlags builds the default notifier.Config shared by every
+// Alert, later overridden per-Alert by any notifierRef.
+func notifierConfigFromFlags() notifier.Config {
+	var smtpTos []string
+	for _, addr := range strings.Split(*smtpTo, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			smtpTos = append(smtpTos, addr)
+		}
+	}
+
+	return notifier.Config{
+		SlackWebhookURL:     *slackWebhook,
+		PagerDutyRoutingKey: *pagerdutyRoutingKey,
+		WebhookURL:          *webhookURL,
+		SMTPHost:            *smtpHost,
+		SMTPPort:            *smtpPort,
+		SMTPUsername:        *smtpUsername,
+		SMTPPassword:        *smtpPassword,
+		SMTPFrom:            *smtpFrom,
+		SMTPTo:              smtpTos,
 	}
-	// add the item to the queue
-	queue.Add(key)
 }
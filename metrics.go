@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics namespace for everything exposed by this controller.
+const metricsNamespace = "k8s_api_pager"
+
+var (
+	workqueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "depth",
+		Help:      "Current depth of the Alert workqueue.",
+	})
+
+	workqueueAdds = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "adds_total",
+		Help:      "Total number of items added to the Alert workqueue.",
+	})
+
+	workqueueRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "retries_total",
+		Help:      "Total number of items requeued after a failed sync.",
+	})
+
+	workqueueLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "latency_seconds",
+		Help:      "How long an item sat in the workqueue before being processed.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	syncDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "sync_duration_seconds",
+		Help:      "How long it took to sync a single Alert.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	reconcileErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of Alert syncs that returned an error.",
+	})
+
+	notifySuccess = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "notify",
+		Name:      "success_total",
+		Help:      "Total number of successful notifier deliveries, by notifier type.",
+	}, []string{"notifier"})
+
+	notifyFailure = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "notify",
+		Name:      "failure_total",
+		Help:      "Total number of failed notifier deliveries, by notifier type.",
+	}, []string{"notifier"})
+)
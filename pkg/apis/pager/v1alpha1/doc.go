@@ -0,0 +1,4 @@
+// +k8s:deepcopy-gen=package
+
+// Package v1alpha1 is the v1alpha1 version of the pager API.
+package v1alpha1
@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Alert is a resource describing a single thing that should page someone.
+// The controller watches Alerts and fans them out to the configured
+// Notifier(s).
+type Alert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertSpec   `json:"spec"`
+	Status AlertStatus `json:"status,omitempty"`
+}
+
+// AlertSpec is the desired state of an Alert.
+type AlertSpec struct {
+	// Description is a human readable summary of the alert, included in the
+	// message sent to whichever Notifier(s) handle it.
+	Description string `json:"description,omitempty"`
+
+	// Severity is a free-form indicator of urgency (e.g. "critical",
+	// "warning") passed through to Notifiers that support it.
+	Severity string `json:"severity,omitempty"`
+
+	// NotifierRef optionally points at a Secret or ConfigMap holding
+	// overrides for the notifier configuration (e.g. a per-team Slack
+	// webhook), layered on top of the controller's default configuration.
+	// +optional
+	NotifierRef *NotifierReference `json:"notifierRef,omitempty"`
+}
+
+// NotifierReference points at a Secret or ConfigMap, in the same namespace
+// as the Alert, holding notifier configuration overrides.
+type NotifierReference struct {
+	// Kind is either "Secret" or "ConfigMap".
+	Kind string `json:"kind"`
+	// Name is the name of the referenced object.
+	Name string `json:"name"`
+}
+
+// AlertPhase summarises the most recent notification attempt for an Alert.
+type AlertPhase string
+
+const (
+	// AlertPhasePending means the Alert has not yet been delivered to any
+	// Notifier.
+	AlertPhasePending AlertPhase = "Pending"
+	// AlertPhaseSent means the Alert was successfully delivered to every
+	// configured Notifier on its current generation.
+	AlertPhaseSent AlertPhase = "Sent"
+	// AlertPhaseFailed means delivery to at least one configured Notifier
+	// failed on its current generation; the workqueue will retry.
+	AlertPhaseFailed AlertPhase = "Failed"
+)
+
+// AlertConditionType is a type of condition reported on an Alert's status.
+type AlertConditionType string
+
+// AlertConditionNotified is true once every configured Notifier has
+// successfully delivered the Alert's current generation.
+const AlertConditionNotified AlertConditionType = "Notified"
+
+// AlertCondition describes a single observation of an Alert's state.
+type AlertCondition struct {
+	Type               AlertConditionType     `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// AlertStatus is the observed state of an Alert.
+type AlertStatus struct {
+	// ObservedGeneration is the .metadata.generation that was last acted
+	// on, used to dedup notifications across informer resyncs.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase summarises the result of the most recent notification attempt.
+	// +optional
+	Phase AlertPhase `json:"phase,omitempty"`
+
+	// LastNotifiedTime is when a Notifier was last invoked for this Alert.
+	// +optional
+	LastNotifiedTime *metav1.Time `json:"lastNotifiedTime,omitempty"`
+
+	// Conditions holds per-notification details, including failures.
+	// +optional
+	Conditions []AlertCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertList is a list of Alerts.
+type AlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Alert `json:"items"`
+}
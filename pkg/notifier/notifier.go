@@ -0,0 +1,68 @@
+// Package notifier fans an Alert out to whoever is supposed to be paged.
+// Concrete implementations register themselves with Register, keyed by the
+// name used on the --notifier flag (e.g. "slack", "pagerduty").
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+)
+
+// Notifier delivers an Alert to whoever is supposed to be paged.
+type Notifier interface {
+	// Name is the registry name this Notifier was constructed under (e.g.
+	// "slack"), used to label metrics and log lines.
+	Name() string
+	Notify(ctx context.Context, alert *v1alpha1.Alert) error
+}
+
+// Config carries the settings needed to construct any of the built-in
+// Notifiers. Fields irrelevant to a given notifier are simply ignored.
+// Values are seeded from CLI flags (environment variables are not read),
+// and may be overridden per-Alert by a referenced Secret or ConfigMap.
+type Config struct {
+	SlackWebhookURL string
+
+	PagerDutyRoutingKey string
+
+	WebhookURL string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+}
+
+// Factory constructs a Notifier from Config.
+type Factory func(cfg Config) (Notifier, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a Notifier implementation to the registry under name, so it
+// can be selected with --notifier=name. Implementations call this from an
+// init() function.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New constructs the named Notifier using cfg.
+func New(name string, cfg Config) (Notifier, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notifier %q (known notifiers: %v)", name, Names())
+	}
+	return factory(cfg)
+}
+
+// Names returns the names of all registered Notifiers.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
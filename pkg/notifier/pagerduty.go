@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+)
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func init() {
+	Register("pagerduty", newPagerDutyNotifier)
+}
+
+type pagerDutyNotifier struct {
+	routingKey string
+	client     *http.Client
+}
+
+func newPagerDutyNotifier(cfg Config) (Notifier, error) {
+	if cfg.PagerDutyRoutingKey == "" {
+		return nil, fmt.Errorf("pagerduty notifier requires --pagerduty-routing-key (or a notifierRef override)")
+	}
+	return &pagerDutyNotifier{routingKey: cfg.PagerDutyRoutingKey, client: http.DefaultClient}, nil
+}
+
+func (p *pagerDutyNotifier) Name() string { return "pagerduty" }
+
+type pagerDutyEvent struct {
+	RoutingKey  string                `json:"routing_key"`
+	EventAction string                `json:"event_action"`
+	DedupKey    string                `json:"dedup_key"`
+	Payload     pagerDutyEventPayload `json:"payload"`
+}
+
+type pagerDutyEventPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+func (p *pagerDutyNotifier) Notify(ctx context.Context, alert *v1alpha1.Alert) error {
+	severity := alert.Spec.Severity
+	if severity == "" {
+		severity = "critical"
+	}
+
+	body, err := json.Marshal(pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    fmt.Sprintf("%s/%s", alert.Namespace, alert.Name),
+		Payload: pagerDutyEventPayload{
+			Summary:  alert.Spec.Description,
+			Source:   fmt.Sprintf("%s/%s", alert.Namespace, alert.Name),
+			Severity: severity,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pagerDutyEventsURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to pagerduty events api: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pagerduty events api returned unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
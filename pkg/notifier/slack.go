@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+)
+
+func init() {
+	Register("slack", newSlackNotifier)
+}
+
+type slackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func newSlackNotifier(cfg Config) (Notifier, error) {
+	if cfg.SlackWebhookURL == "" {
+		return nil, fmt.Errorf("slack notifier requires --slack-webhook (or a notifierRef override)")
+	}
+	return &slackNotifier{webhookURL: cfg.SlackWebhookURL, client: http.DefaultClient}, nil
+}
+
+func (s *slackNotifier) Name() string { return "slack" }
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, alert *v1alpha1.Alert) error {
+	body, err := json.Marshal(slackMessage{
+		Text: fmt.Sprintf(":rotating_light: *%s* alert `%s/%s`: %s", alert.Spec.Severity, alert.Namespace, alert.Name, alert.Spec.Description),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to slack webhook: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/munnerz/k8s-api-pager-demo/pkg/apis/pager/v1alpha1"
+)
+
+func init() {
+	Register("webhook", newWebhookNotifier)
+}
+
+// webhookNotifier POSTs the Alert, verbatim, to an arbitrary HTTP endpoint.
+// It exists for destinations not covered by a dedicated Notifier.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookNotifier(cfg Config) (Notifier, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("webhook notifier requires --webhook-url (or a notifierRef override)")
+	}
+	return &webhookNotifier{url: cfg.WebhookURL, client: http.DefaultClient}, nil
+}
+
+func (w *webhookNotifier) Name() string { return "webhook" }
+
+func (w *webhookNotifier) Notify(ctx context.Context, alert *v1alpha1.Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to webhook: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}